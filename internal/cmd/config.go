@@ -0,0 +1,42 @@
+// Package cmd holds the sqlc CLI's cobra commands.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// ConfigCmd groups subcommands that operate on a sqlc config file without
+// running code generation.
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Work with sqlc configuration files",
+}
+
+func init() {
+	ConfigCmd.AddCommand(validateCmd)
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Validate a sqlc.json or sqlc.yaml config file",
+	Long: `validate checks a config file against the sqlc JSON Schema and
+runs the same checks ParseConfigFile would (global override rules and each
+override's Parse) without generating any code. It's meant to be fast enough
+to run from an editor or a CI lint step.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "sqlc.json"
+		if len(args) == 1 {
+			path = args[0]
+		}
+		if err := config.ValidateConfigFile(path); err != nil {
+			return fmt.Errorf("%s is invalid: %w", path, err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s is valid\n", path)
+		return nil
+	},
+}
@@ -4,12 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"go/token"
 	"go/types"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/kyleconroy/sqlc/internal/pg"
 )
 
@@ -29,10 +32,117 @@ The only supported version is "1".
 const errMessageNoPackages = `No packages are configured`
 
 type GenerateSettings struct {
-	Version   string            `json:"version"`
-	Packages  []PackageSettings `json:"packages"`
-	Overrides []Override        `json:"overrides,omitempty"`
-	Rename    map[string]string `json:"rename,omitempty"`
+	Version   string            `json:"version" yaml:"version"`
+	Packages  []PackageSettings `json:"packages" yaml:"packages"`
+	Overrides []Override        `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+	Rename    map[string]string `json:"rename,omitempty" yaml:"rename,omitempty"`
+	Plugins   []PluginSettings  `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+
+	// Extends lists other config files, relative to this one, whose
+	// `rename` and `overrides` are merged in before this file's own. This
+	// lets a team share a canonical set of overrides (ksuid, uuid, JSONB,
+	// enums, ...) across many services without copy-paste. Only resolved
+	// by ParseConfigFile; a fragment named here must not declare `version`
+	// or `packages`.
+	Extends []string `json:"extends,omitempty" yaml:"extends,omitempty"`
+}
+
+// PluginSettings names a single plugin to load into the code-generation
+// pipeline, along with whatever configuration that plugin needs.
+type PluginSettings struct {
+	// Name identifies the plugin in error messages.
+	// There is no built-in plugin registry; Name is an opaque label chosen
+	// by whatever loads Process or SharedObject.
+	Name string `json:"name" yaml:"name"`
+
+	// Process, when set, is the path to an out-of-process plugin binary
+	// invoked over stdio with a JSON-encoded request/response protocol.
+	// Mutually exclusive with SharedObject.
+	Process string `json:"process,omitempty" yaml:"process,omitempty"`
+
+	// SharedObject, when set, is the path to a Go plugin `.so` loaded via
+	// the standard library `plugin` package.
+	SharedObject string `json:"shared_object,omitempty" yaml:"shared_object,omitempty"`
+
+	// Packages restricts this plugin to the named packages, matched against
+	// PackageSettings.Name. An empty list applies the plugin to every
+	// package, mirroring how Override.Languages scopes an override to a
+	// subset of languages.
+	Packages []string `json:"packages,omitempty" yaml:"packages,omitempty"`
+
+	// Config is passed through verbatim to the plugin; sqlc does not
+	// interpret it.
+	Config PluginConfig `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// PluginConfig holds a plugin's opaque, plugin-specific configuration as
+// JSON, regardless of whether the surrounding sqlc config was written as
+// JSON or YAML. json.RawMessage only implements json.Unmarshaler, so a YAML
+// config whose `plugins[].config` is anything but a bare string (a mapping,
+// a sequence, ...) would otherwise fail with "cannot unmarshal !!map into
+// json.RawMessage". UnmarshalYAML instead decodes into a generic document
+// and re-marshals it to JSON, so plugins always receive their config the
+// same way no matter which format the user wrote.
+type PluginConfig json.RawMessage
+
+func (c PluginConfig) MarshalJSON() ([]byte, error) {
+	if len(c) == 0 {
+		return []byte("null"), nil
+	}
+	return c, nil
+}
+
+func (c *PluginConfig) UnmarshalJSON(data []byte) error {
+	*c = append((*c)[:0], data...)
+	return nil
+}
+
+func (c PluginConfig) MarshalYAML() (interface{}, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	var doc interface{}
+	if err := json.Unmarshal(c, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (c *PluginConfig) UnmarshalYAML(node *yaml.Node) error {
+	var doc interface{}
+	if err := node.Decode(&doc); err != nil {
+		return err
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	*c = data
+	return nil
+}
+
+// appliesToPackage reports whether this plugin is in scope for the named
+// package. A plugin with no Packages list applies to every package.
+func (p *PluginSettings) appliesToPackage(name string) bool {
+	if len(p.Packages) == 0 {
+		return true
+	}
+	for _, pkg := range p.Packages {
+		if pkg == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *PluginSettings) Parse() error {
+	if p.Name == "" {
+		return fmt.Errorf("plugin entry is missing a `name`")
+	}
+	if p.Process != "" && p.SharedObject != "" {
+		return fmt.Errorf(`plugin %q cannot specify both "process" and "shared_object"`, p.Name)
+	}
+	return nil
 }
 
 type Engine string
@@ -42,40 +152,134 @@ const (
 	EnginePostgreSQL Engine = "postgresql"
 )
 
+type Language string
+
+const (
+	LanguageGo         Language = "go"
+	LanguageKotlin     Language = "kotlin"
+	LanguageTypeScript Language = "typescript"
+)
+
 type PackageSettings struct {
-	Name                string     `json:"name"`
-	Engine              Engine     `json:"engine,omitempty"`
-	Path                string     `json:"path"`
-	Schema              string     `json:"schema"`
-	Queries             string     `json:"queries"`
-	EmitInterface       bool       `json:"emit_interface"`
-	EmitJSONTags        bool       `json:"emit_json_tags"`
-	EmitPreparedQueries bool       `json:"emit_prepared_queries"`
-	Overrides           []Override `json:"overrides"`
+	Name   string `json:"name" yaml:"name"`
+	Engine Engine `json:"engine,omitempty" yaml:"engine,omitempty"`
+
+	// Language selects which code-generation target this package builds
+	// for and which language-scoped Override/TypeName entries apply to it.
+	// Defaults to LanguageGo. There is currently only a Go emitter's worth
+	// of config to select between; Kotlin and TypeScript are parsed and
+	// validated the same as Go but have no matching emitter in this tree.
+	Language Language `json:"language,omitempty" yaml:"language,omitempty"`
+
+	Path                string     `json:"path" yaml:"path"`
+	Schema              string     `json:"schema" yaml:"schema"`
+	Queries             string     `json:"queries" yaml:"queries"`
+	EmitInterface       bool       `json:"emit_interface" yaml:"emit_interface"`
+	EmitJSONTags        bool       `json:"emit_json_tags" yaml:"emit_json_tags"`
+	EmitPreparedQueries bool       `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
+	Overrides           []Override `json:"overrides" yaml:"overrides"`
+
+	// Include lists per-package override fragments, relative to the config
+	// file, merged into Overrides before this package's own overrides are
+	// applied. Only resolved by ParseConfigFile; a fragment named here must
+	// not declare `version` or `packages`.
+	Include []string `json:"include,omitempty" yaml:"include,omitempty"`
 }
 
+// Override describes a single type binding, from a column or a database type
+// to a Go (or other language) type. This package only parses and validates
+// that binding; there is no Go code generator in this tree yet to consume
+// Pointer, ScanFunc, ValueFunc, Alias, or PreferMethod when emitting struct
+// fields, Scan calls, or prepared-statement argument encoding. Until such an
+// emitter exists here, setting those fields changes nothing about generated
+// code.
 type Override struct {
 	// name of the golang type to use, e.g. `github.com/segmentio/ksuid.KSUID`
-	GoType string `json:"go_type"`
+	GoType string `json:"go_type" yaml:"go_type"`
 
 	// fully qualified name of the Go type, e.g. `github.com/segmentio/ksuid.KSUID`
-	DBType                  string `json:"db_type"`
-	Deprecated_PostgresType string `json:"postgres_type"`
+	DBType                  string `json:"db_type" yaml:"db_type"`
+	Deprecated_PostgresType string `json:"postgres_type" yaml:"postgres_type"`
 
 	// for global overrides only when two different engines are in use
-	Engine Engine `json:"engine,omitempty"`
+	Engine Engine `json:"engine,omitempty" yaml:"engine,omitempty"`
 
 	// True if the GoType should override if the maching postgres type is nullable
-	Null bool `json:"null"`
+	Null bool `json:"null" yaml:"null"`
 
 	// fully qualified name of the column, e.g. `accounts.id`
-	Column string `json:"column"`
+	Column string `json:"column" yaml:"column"`
+
+	// Languages restricts this override to the named code-generation
+	// targets. An empty list applies the override to every language. This
+	// lets a single override declare target-specific types, e.g. a `uuid`
+	// column that maps to `github.com/google/uuid.UUID` for Go and `string`
+	// for TypeScript.
+	Languages []Language `json:"languages,omitempty" yaml:"languages,omitempty"`
+
+	// TypeName maps a language to the type name to use for that language,
+	// e.g. {"kotlin": "java.util.UUID", "typescript": "string"}. For the
+	// "go" language, GoType is used instead and TypeName is ignored.
+	TypeName map[Language]string `json:"type_name,omitempty" yaml:"type_name,omitempty"`
+
+	// Pointer makes the generated column type `*GoType`, independent of
+	// whether the matching column is nullable. Unlike Null, this doesn't
+	// change which columns the override applies to, only whether the
+	// resulting field is a pointer.
+	Pointer bool `json:"pointer,omitempty" yaml:"pointer,omitempty"`
+
+	// ScanFunc is the fully qualified name of a function used to wrap
+	// `Scan` calls for this type, e.g.
+	// `github.com/example/sqltypes.ScanKSUID`. Use this to bind to
+	// third-party types that don't implement sql.Scanner themselves. The
+	// function must have the signature `func(dest *GoType, src any) error`.
+	ScanFunc string `json:"scan_func,omitempty" yaml:"scan_func,omitempty"`
 
-	ColumnName  string
-	Table       pg.FQN
-	GoTypeName  string
-	GoPackage   string
-	GoBasicType bool
+	// ValueFunc is the fully qualified name of a function used to wrap
+	// `driver.Value` calls for this type, the inverse of ScanFunc. The
+	// function must have the signature `func(GoType) (driver.Value, error)`.
+	ValueFunc string `json:"value_func,omitempty" yaml:"value_func,omitempty"`
+
+	// Alias, when true, makes GoType refer to a named type declared inside
+	// the generated package (`type X = underlying`) rather than an import
+	// from an external package. GoType must be an unqualified identifier.
+	Alias bool `json:"alias,omitempty" yaml:"alias,omitempty"`
+
+	// PreferMethod names a method on GoType that the emitter should call
+	// instead of going through ScanFunc/ValueFunc or a raw field access,
+	// e.g. "String" to prefer a `func (t GoType) String() string` accessor.
+	// This package only parses config and has no loaded Go type
+	// information to inspect, so method resolution is declared here, not
+	// discovered by reflection: a Go emitter that does load the target
+	// package is expected to look this name up on GoType and fall back to
+	// ScanFunc/ValueFunc if it isn't found. Automatic discovery without a
+	// declared name is out of scope until such an emitter exists in this
+	// tree.
+	PreferMethod string `json:"prefer_method,omitempty" yaml:"prefer_method,omitempty"`
+
+	ColumnName    string
+	Table         pg.FQN
+	GoTypeName    string
+	GoPackage     string
+	GoBasicType   bool
+	ScanFuncName  string
+	ScanFuncPkg   string
+	ValueFuncName string
+	ValueFuncPkg  string
+}
+
+// appliesToLanguage reports whether this override is in scope for the given
+// language. An override with no Languages list applies to every language.
+func (o *Override) appliesToLanguage(lang Language) bool {
+	if len(o.Languages) == 0 {
+		return true
+	}
+	for _, l := range o.Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *GenerateSettings) ValidateGlobalOverrides() error {
@@ -132,6 +336,43 @@ func (o *Override) Parse() error {
 		}
 	}
 
+	// validate Languages
+	for _, lang := range o.Languages {
+		switch lang {
+		case LanguageGo, LanguageKotlin, LanguageTypeScript:
+		default:
+			return fmt.Errorf("Override `languages` entry %q is not a supported code-generation language", lang)
+		}
+	}
+
+	// an override scoped away from Go need not specify a go_type; its type
+	// for other languages comes from TypeName instead, so require an entry
+	// for each language it claims to support
+	if !o.appliesToLanguage(LanguageGo) && o.GoType == "" {
+		for _, lang := range o.Languages {
+			if lang == LanguageGo {
+				continue
+			}
+			if o.TypeName[lang] == "" {
+				return fmt.Errorf("Override for language %q must specify `go_type` or a `type_name` entry for %q", lang, lang)
+			}
+		}
+		return nil
+	}
+
+	// validate Alias: GoType names a type declared inside the generated
+	// package itself, so it must be a bare identifier, not an import path.
+	if o.Alias {
+		if o.GoType == "" {
+			return fmt.Errorf("Override must specify `go_type` when `alias` is set")
+		}
+		if !token.IsIdentifier(o.GoType) {
+			return fmt.Errorf("Package override `go_type` specifier %q must be a bare identifier when `alias` is set", o.GoType)
+		}
+		o.GoTypeName = o.GoType
+		return o.parseFuncOverrides()
+	}
+
 	// validate GoType
 	lastDot := strings.LastIndex(o.GoType, ".")
 	lastSlash := strings.LastIndex(o.GoType, "/")
@@ -183,9 +424,44 @@ func (o *Override) Parse() error {
 		o.GoTypeName = "*" + o.GoTypeName
 	}
 
+	return o.parseFuncOverrides()
+}
+
+// parseFuncOverrides validates and splits the ScanFunc and ValueFunc fields,
+// which share the `package.Function` format used by GoType.
+func (o *Override) parseFuncOverrides() error {
+	if o.PreferMethod != "" && !token.IsIdentifier(o.PreferMethod) {
+		return fmt.Errorf("Override `prefer_method` specifier %q is not a valid Go identifier", o.PreferMethod)
+	}
+	if o.ScanFunc != "" {
+		pkg, name, err := splitQualifiedFuncName(o.ScanFunc)
+		if err != nil {
+			return fmt.Errorf("Override `scan_func` specifier %q is not the proper format, expected 'package.Function', e.g. 'github.com/example/sqltypes.ScanKSUID': %w", o.ScanFunc, err)
+		}
+		o.ScanFuncPkg, o.ScanFuncName = pkg, name
+	}
+	if o.ValueFunc != "" {
+		pkg, name, err := splitQualifiedFuncName(o.ValueFunc)
+		if err != nil {
+			return fmt.Errorf("Override `value_func` specifier %q is not the proper format, expected 'package.Function', e.g. 'github.com/example/sqltypes.ValueKSUID': %w", o.ValueFunc, err)
+		}
+		o.ValueFuncPkg, o.ValueFuncName = pkg, name
+	}
 	return nil
 }
 
+// splitQualifiedFuncName splits a fully qualified function name such as
+// `github.com/segmentio/ksuid.Parse` into its package import path and the
+// bare function name.
+func splitQualifiedFuncName(qualified string) (pkg string, name string, err error) {
+	lastDot := strings.LastIndex(qualified, ".")
+	lastSlash := strings.LastIndex(qualified, "/")
+	if lastDot == -1 || lastSlash == -1 || lastDot < lastSlash {
+		return "", "", fmt.Errorf("missing package path")
+	}
+	return qualified[:lastDot], qualified[lastDot+1:], nil
+}
+
 var ErrMissingVersion = errors.New("no version number")
 var ErrUnknownVersion = errors.New("invalid version number")
 var ErrNoPackages = errors.New("no packages")
@@ -199,6 +475,210 @@ func ParseConfig(rd io.Reader) (GenerateSettings, error) {
 	if err := dec.Decode(&config); err != nil {
 		return config, err
 	}
+	return finalizeConfig(config)
+}
+
+// ParseConfigFile parses the sqlc config at path, resolving `extends` and
+// per-package `include` fragments relative to the config file's directory
+// before validating the merged result. Use this instead of ParseConfig
+// whenever the config comes from disk, since relative fragment paths can
+// only be resolved with a known base directory.
+func ParseConfigFile(path string) (GenerateSettings, error) {
+	config, err := resolveExtends(path, nil)
+	if err != nil {
+		return GenerateSettings{}, err
+	}
+	dir := filepath.Dir(path)
+	for i := range config.Packages {
+		merged, err := resolveIncludes(dir, config.Packages[i])
+		if err != nil {
+			return GenerateSettings{}, err
+		}
+		config.Packages[i] = merged
+	}
+	return finalizeConfig(config)
+}
+
+// resolveExtends decodes the config file at path and recursively merges in
+// the fragments named by its `extends` field, in order, with later entries
+// (and the file itself) taking precedence. ancestors is the stack of
+// absolute paths currently being resolved on the path from the root config
+// down to path's parent; a file is only a cycle if it reappears on that
+// stack, not merely because two independent branches both extend it (a
+// diamond, which is the common "share overrides across services" shape and
+// must be allowed).
+func resolveExtends(path string, ancestors []string) (GenerateSettings, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return GenerateSettings{}, err
+	}
+	for _, ancestor := range ancestors {
+		if ancestor == abs {
+			return GenerateSettings{}, fmt.Errorf("config cycle detected: %q is its own ancestor via `extends`", path)
+		}
+	}
+	ancestors = append(ancestors, abs)
+
+	config, err := decodeConfigFile(path)
+	if err != nil {
+		return GenerateSettings{}, err
+	}
+
+	dir := filepath.Dir(path)
+	merged := GenerateSettings{}
+	for _, extend := range config.Extends {
+		fragment, err := resolveExtends(filepath.Join(dir, extend), ancestors)
+		if err != nil {
+			return GenerateSettings{}, err
+		}
+		if fragment.Version != "" || len(fragment.Packages) > 0 {
+			return GenerateSettings{}, fmt.Errorf("extended config %q must not declare `version` or `packages`; only the root config may", extend)
+		}
+		merged = mergeConfig(merged, fragment)
+	}
+	return mergeConfig(merged, config), nil
+}
+
+// resolveIncludes merges the override fragments named by pkg.Include, in
+// order, into pkg.Overrides, with pkg's own overrides taking precedence over
+// any included entry for the same column or db_type.
+func resolveIncludes(dir string, pkg PackageSettings) (PackageSettings, error) {
+	overrides := []Override{}
+	for _, include := range pkg.Include {
+		fragment, err := decodeConfigFile(filepath.Join(dir, include))
+		if err != nil {
+			return pkg, err
+		}
+		if fragment.Version != "" || len(fragment.Packages) > 0 {
+			return pkg, fmt.Errorf("included fragment %q must not declare `version` or `packages`; only the root config may", include)
+		}
+		overrides = mergeOverrides(overrides, fragment.Overrides)
+	}
+	pkg.Overrides = mergeOverrides(overrides, pkg.Overrides)
+	return pkg, nil
+}
+
+// decodeConfigFile reads the config file at path without validating it;
+// callers finish validation once the full `extends`/`include` chain has been
+// merged. The format is chosen by file extension: `.yaml`/`.yml` decode as
+// YAML, everything else (including the conventional `sqlc.json`) as JSON.
+// Both formats reject unknown fields so a typo in a key surfaces immediately
+// instead of being silently ignored.
+func decodeConfigFile(path string) (GenerateSettings, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return GenerateSettings{}, err
+	}
+	defer f.Close()
+
+	var config GenerateSettings
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err := dec.Decode(&config); err != nil {
+			return GenerateSettings{}, err
+		}
+	default:
+		dec := json.NewDecoder(f)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&config); err != nil {
+			return GenerateSettings{}, err
+		}
+	}
+	return config, nil
+}
+
+// decodeConfigDocument reads the config file at path into a generic document
+// (map[string]interface{}/[]interface{}/...) suitable for JSON Schema
+// validation, using the same extension-based format detection as
+// decodeConfigFile.
+func decodeConfigDocument(path string) (interface{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var doc interface{}
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		if err := yaml.NewDecoder(f).Decode(&doc); err != nil {
+			return nil, err
+		}
+	default:
+		if err := json.NewDecoder(f).Decode(&doc); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// mergeConfig combines base with overlay, with overlay's `version` and
+// `packages` winning whenever set, `rename` entries merged key-by-key with
+// overlay winning on conflicts, and `overrides` concatenated with overlay
+// winning on conflicting `column`/`db_type` keys.
+func mergeConfig(base, overlay GenerateSettings) GenerateSettings {
+	merged := base
+	if overlay.Version != "" {
+		merged.Version = overlay.Version
+	}
+	if len(overlay.Packages) > 0 {
+		merged.Packages = overlay.Packages
+	}
+	merged.Rename = mergeRename(base.Rename, overlay.Rename)
+	merged.Overrides = mergeOverrides(base.Overrides, overlay.Overrides)
+	merged.Plugins = append(append([]PluginSettings{}, base.Plugins...), overlay.Plugins...)
+	return merged
+}
+
+func mergeRename(base, overlay map[string]string) map[string]string {
+	if len(base) == 0 && len(overlay) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(overlay))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		merged[k] = v
+	}
+	return merged
+}
+
+// overrideKey identifies an Override for the purposes of merge precedence: a
+// later entry with the same column, or the same db_type, replaces an earlier
+// one instead of appending a duplicate.
+func overrideKey(o Override) string {
+	if o.Column != "" {
+		return "column:" + o.Column
+	}
+	dbType := o.DBType
+	if dbType == "" {
+		dbType = o.Deprecated_PostgresType
+	}
+	return "db_type:" + string(o.Engine) + ":" + dbType
+}
+
+func mergeOverrides(base, overlay []Override) []Override {
+	merged := append([]Override{}, base...)
+	index := make(map[string]int, len(merged))
+	for i, o := range merged {
+		index[overrideKey(o)] = i
+	}
+	for _, o := range overlay {
+		key := overrideKey(o)
+		if i, ok := index[key]; ok {
+			merged[i] = o
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, o)
+	}
+	return merged
+}
+
+func finalizeConfig(config GenerateSettings) (GenerateSettings, error) {
 	if config.Version == "" {
 		return config, ErrMissingVersion
 	}
@@ -216,6 +696,11 @@ func ParseConfig(rd io.Reader) (GenerateSettings, error) {
 			return config, err
 		}
 	}
+	for i := range config.Plugins {
+		if err := config.Plugins[i].Parse(); err != nil {
+			return config, err
+		}
+	}
 	for j := range config.Packages {
 		if config.Packages[j].Path == "" {
 			return config, ErrNoPackagePath
@@ -231,6 +716,9 @@ func ParseConfig(rd io.Reader) (GenerateSettings, error) {
 		if config.Packages[j].Engine == "" {
 			config.Packages[j].Engine = EnginePostgreSQL
 		}
+		if config.Packages[j].Language == "" {
+			config.Packages[j].Language = LanguageGo
+		}
 	}
 	return config, nil
 }
@@ -239,12 +727,27 @@ type CombinedSettings struct {
 	Global    GenerateSettings
 	Package   PackageSettings
 	Overrides []Override
+	Plugins   []PluginSettings
 }
 
 func Combine(gen GenerateSettings, pkg PackageSettings) CombinedSettings {
+	merged := append(gen.Overrides, pkg.Overrides...)
+	overrides := make([]Override, 0, len(merged))
+	for _, o := range merged {
+		if o.appliesToLanguage(pkg.Language) {
+			overrides = append(overrides, o)
+		}
+	}
+	plugins := make([]PluginSettings, 0, len(gen.Plugins))
+	for _, p := range gen.Plugins {
+		if p.appliesToPackage(pkg.Name) {
+			plugins = append(plugins, p)
+		}
+	}
 	return CombinedSettings{
 		Global:    gen,
 		Package:   pkg,
-		Overrides: append(gen.Overrides, pkg.Overrides...),
+		Overrides: overrides,
+		Plugins:   plugins,
 	}
 }
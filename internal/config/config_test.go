@@ -0,0 +1,292 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestParseConfigFileExtendsDiamond(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "common.json", `{
+		"overrides": [
+			{"column": "accounts.id", "go_type": "github.com/segmentio/ksuid.KSUID"}
+		]
+	}`)
+	writeFile(t, dir, "a.json", `{"extends": ["common.json"]}`)
+	writeFile(t, dir, "b.json", `{"extends": ["common.json"]}`)
+	root := writeFile(t, dir, "root.json", `{
+		"version": "1",
+		"extends": ["a.json", "b.json"],
+		"packages": [{"path": "gen", "schema": "schema.sql", "queries": "query.sql"}]
+	}`)
+
+	cfg, err := ParseConfigFile(root)
+	if err != nil {
+		t.Fatalf("diamond extends should not be reported as a cycle: %v", err)
+	}
+	if len(cfg.Overrides) != 1 {
+		t.Fatalf("expected the shared override to be merged exactly once, got %d: %+v", len(cfg.Overrides), cfg.Overrides)
+	}
+}
+
+func TestParseConfigFileExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.json", `{"extends": ["b.json"]}`)
+	writeFile(t, dir, "b.json", `{"extends": ["a.json"]}`)
+	root := writeFile(t, dir, "root.json", `{
+		"version": "1",
+		"extends": ["a.json"],
+		"packages": [{"path": "gen", "schema": "schema.sql", "queries": "query.sql"}]
+	}`)
+
+	if _, err := ParseConfigFile(root); err == nil {
+		t.Fatal("expected an actual extends cycle to be rejected")
+	}
+}
+
+func TestMergeOverridesPrecedence(t *testing.T) {
+	base := []Override{
+		{Column: "accounts.id", GoType: "string"},
+		{DBType: "uuid", GoType: "string"},
+	}
+	overlay := []Override{
+		{Column: "accounts.id", GoType: "github.com/segmentio/ksuid.KSUID"},
+		{Column: "accounts.email", GoType: "string"},
+	}
+
+	merged := mergeOverrides(base, overlay)
+	if len(merged) != 3 {
+		t.Fatalf("expected 3 overrides after merge, got %d: %+v", len(merged), merged)
+	}
+	if merged[0].GoType != "github.com/segmentio/ksuid.KSUID" {
+		t.Fatalf("expected overlay to win for a conflicting column, got %q", merged[0].GoType)
+	}
+	if merged[1].DBType != "uuid" {
+		t.Fatalf("expected non-conflicting base entry to survive, got %+v", merged[1])
+	}
+	if merged[2].Column != "accounts.email" {
+		t.Fatalf("expected non-conflicting overlay entry to be appended, got %+v", merged[2])
+	}
+}
+
+func TestOverrideParseAliasRequiresGoType(t *testing.T) {
+	o := Override{Column: "accounts.id", Alias: true}
+	if err := o.Parse(); err == nil {
+		t.Fatal("expected alias override with no go_type to fail validation")
+	}
+}
+
+func TestOverrideParseAlias(t *testing.T) {
+	o := Override{Column: "accounts.id", Alias: true, GoType: "AccountID"}
+	if err := o.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.GoTypeName != "AccountID" {
+		t.Fatalf("expected GoTypeName %q, got %q", "AccountID", o.GoTypeName)
+	}
+}
+
+func TestOverrideParseNonGoRequiresTypeName(t *testing.T) {
+	o := Override{Column: "accounts.kind", Languages: []Language{LanguageKotlin}}
+	if err := o.Parse(); err == nil {
+		t.Fatal("expected a kotlin-only override with no go_type and no type_name to fail validation")
+	}
+}
+
+func TestOverrideParseNonGoWithTypeName(t *testing.T) {
+	o := Override{
+		Column:    "accounts.kind",
+		Languages: []Language{LanguageKotlin},
+		TypeName:  map[Language]string{LanguageKotlin: "java.util.UUID"},
+	}
+	if err := o.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOverrideParseInvalidLanguage(t *testing.T) {
+	o := Override{Column: "accounts.id", GoType: "string", Languages: []Language{"rust"}}
+	if err := o.Parse(); err == nil {
+		t.Fatal("expected unsupported language to fail validation")
+	}
+}
+
+func TestOverrideParseScanValueFunc(t *testing.T) {
+	o := Override{
+		Column:    "accounts.id",
+		GoType:    "github.com/segmentio/ksuid.KSUID",
+		ScanFunc:  "github.com/example/sqltypes.ScanKSUID",
+		ValueFunc: "github.com/example/sqltypes.ValueKSUID",
+	}
+	if err := o.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.ScanFuncPkg != "github.com/example/sqltypes" || o.ScanFuncName != "ScanKSUID" {
+		t.Fatalf("unexpected scan func split: pkg=%q name=%q", o.ScanFuncPkg, o.ScanFuncName)
+	}
+	if o.ValueFuncPkg != "github.com/example/sqltypes" || o.ValueFuncName != "ValueKSUID" {
+		t.Fatalf("unexpected value func split: pkg=%q name=%q", o.ValueFuncPkg, o.ValueFuncName)
+	}
+}
+
+func TestOverrideParseScanFuncMissingPackage(t *testing.T) {
+	o := Override{Column: "accounts.id", GoType: "string", ScanFunc: "NotQualified"}
+	if err := o.Parse(); err == nil {
+		t.Fatal("expected an unqualified scan_func to fail validation")
+	}
+}
+
+func TestOverrideParsePreferMethod(t *testing.T) {
+	valid := Override{Column: "accounts.id", GoType: "string", PreferMethod: "String"}
+	if err := valid.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	invalid := Override{Column: "accounts.id", GoType: "string", PreferMethod: "not an identifier"}
+	if err := invalid.Parse(); err == nil {
+		t.Fatal("expected an invalid prefer_method identifier to fail validation")
+	}
+}
+
+func TestPluginSettingsParseRejectsProcessAndSharedObject(t *testing.T) {
+	p := PluginSettings{Name: "mocks", Process: "./mocks", SharedObject: "./mocks.so"}
+	if err := p.Parse(); err == nil {
+		t.Fatal("expected a plugin with both process and shared_object to fail validation")
+	}
+}
+
+func TestPluginConfigYAMLMapping(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "sqlc.yaml", "version: \"1\"\n"+
+		"packages:\n"+
+		"  - path: gen\n"+
+		"    schema: schema.sql\n"+
+		"    queries: query.sql\n"+
+		"plugins:\n"+
+		"  - name: mocks\n"+
+		"    process: ./mocks\n"+
+		"    config:\n"+
+		"      style: testify\n"+
+		"      package: mocks\n")
+
+	cfg, err := ParseConfigFile(path)
+	if err != nil {
+		t.Fatalf("expected a yaml plugin config with a nested mapping to parse: %v", err)
+	}
+	if len(cfg.Plugins) != 1 {
+		t.Fatalf("expected 1 plugin, got %d", len(cfg.Plugins))
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal(cfg.Plugins[0].Config, &decoded); err != nil {
+		t.Fatalf("expected plugin config to round-trip through json: %v", err)
+	}
+	if decoded["style"] != "testify" || decoded["package"] != "mocks" {
+		t.Fatalf("unexpected decoded plugin config: %+v", decoded)
+	}
+}
+
+func TestCombineFiltersOverridesByLanguage(t *testing.T) {
+	gen := GenerateSettings{
+		Overrides: []Override{
+			{Column: "accounts.id", GoType: "string"},
+			{Column: "accounts.kind", GoType: "string", Languages: []Language{LanguageKotlin}},
+		},
+	}
+	pkg := PackageSettings{Language: LanguageGo}
+
+	combined := Combine(gen, pkg)
+	if len(combined.Overrides) != 1 {
+		t.Fatalf("expected only the language-agnostic override to apply to go, got %+v", combined.Overrides)
+	}
+	if combined.Overrides[0].Column != "accounts.id" {
+		t.Fatalf("unexpected override survived filtering: %+v", combined.Overrides[0])
+	}
+}
+
+func TestCombineFiltersPluginsByPackage(t *testing.T) {
+	gen := GenerateSettings{
+		Plugins: []PluginSettings{
+			{Name: "mocks", Process: "./mocks"},
+			{Name: "openapi", Process: "./openapi", Packages: []string{"other"}},
+		},
+	}
+	pkg := PackageSettings{Name: "accounts"}
+
+	combined := Combine(gen, pkg)
+	if len(combined.Plugins) != 1 {
+		t.Fatalf("expected only the unscoped plugin to apply to package %q, got %+v", pkg.Name, combined.Plugins)
+	}
+	if combined.Plugins[0].Name != "mocks" {
+		t.Fatalf("unexpected plugin survived filtering: %+v", combined.Plugins[0])
+	}
+}
+
+func TestMergeRenamePrecedence(t *testing.T) {
+	base := map[string]string{"id": "ID", "url": "URL"}
+	overlay := map[string]string{"id": "Identifier"}
+
+	merged := mergeRename(base, overlay)
+	if merged["id"] != "Identifier" {
+		t.Fatalf("expected overlay to win for a conflicting rename key, got %q", merged["id"])
+	}
+	if merged["url"] != "URL" {
+		t.Fatalf("expected non-conflicting base entry to survive, got %q", merged["url"])
+	}
+}
+
+func TestJSONSchemaCompiles(t *testing.T) {
+	schema, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schema) == 0 {
+		t.Fatal("expected a non-empty schema document")
+	}
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "sqlc.json", `{
+		"version": "1",
+		"packages": [{"path": "gen", "schema": "schema.sql", "queries": "query.sql"}]
+	}`)
+	if err := ValidateConfigFile(path); err != nil {
+		t.Fatalf("expected a valid config to pass validation: %v", err)
+	}
+}
+
+func TestValidateConfigFileRejectsUnknownField(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "sqlc.json", `{
+		"version": "1",
+		"packages": [{"path": "gen", "schema": "schema.sql", "queries": "query.sql"}],
+		"made_up_field": true
+	}`)
+	if err := ValidateConfigFile(path); err == nil {
+		t.Fatal("expected an unknown top-level field to fail schema validation")
+	}
+}
+
+func TestValidateConfigFileYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "sqlc.yaml", "version: \"1\"\n"+
+		"packages:\n"+
+		"  - path: gen\n"+
+		"    schema: schema.sql\n"+
+		"    queries: query.sql\n")
+	if err := ValidateConfigFile(path); err != nil {
+		t.Fatalf("expected a valid yaml config to pass validation: %v", err)
+	}
+}
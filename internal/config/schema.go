@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// schemaURL is the published location of the schema returned by JSONSchema.
+// Editors that support the `$schema`/`yaml-language-server` conventions use
+// it to offer autocomplete and inline validation for sqlc.json/sqlc.yaml.
+const schemaURL = "https://raw.githubusercontent.com/kyleconroy/sqlc/main/sqlc-schema.json"
+
+// JSONSchema returns the JSON Schema (draft 2020-12) describing the sqlc
+// config format. The schema is written out by hand in this file; it is not
+// derived from the GenerateSettings, PackageSettings, Override or
+// PluginSettings struct definitions, so a field added to one of those types
+// is not part of the schema unless it is also added here.
+func JSONSchema() ([]byte, error) {
+	schema := map[string]any{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"$id":      schemaURL,
+		"title":    "sqlc configuration",
+		"type":     "object",
+		"required": []string{"version", "packages"},
+		"properties": map[string]any{
+			"version":  map[string]any{"type": "string", "enum": []string{"1"}},
+			"extends":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"rename":   map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"packages": map[string]any{"type": "array", "items": packageSchema()},
+			"overrides": map[string]any{
+				"type":  "array",
+				"items": overrideSchema(),
+			},
+			"plugins": map[string]any{
+				"type":  "array",
+				"items": pluginSchema(),
+			},
+		},
+		"additionalProperties": false,
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func packageSchema() map[string]any {
+	return map[string]any{
+		"type":     "object",
+		"required": []string{"path", "schema", "queries"},
+		"properties": map[string]any{
+			"name":                  map[string]any{"type": "string"},
+			"engine":                map[string]any{"type": "string", "enum": []string{string(EngineMySQL), string(EnginePostgreSQL)}},
+			"language":              map[string]any{"type": "string", "enum": []string{string(LanguageGo), string(LanguageKotlin), string(LanguageTypeScript)}},
+			"path":                  map[string]any{"type": "string"},
+			"schema":                map[string]any{"type": "string"},
+			"queries":               map[string]any{"type": "string"},
+			"emit_interface":        map[string]any{"type": "boolean"},
+			"emit_json_tags":        map[string]any{"type": "boolean"},
+			"emit_prepared_queries": map[string]any{"type": "boolean"},
+			"overrides":             map[string]any{"type": "array", "items": overrideSchema()},
+			"include":               map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+		"additionalProperties": false,
+	}
+}
+
+func overrideSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"go_type":       map[string]any{"type": "string"},
+			"db_type":       map[string]any{"type": "string"},
+			"postgres_type": map[string]any{"type": "string"},
+			"engine":        map[string]any{"type": "string", "enum": []string{string(EngineMySQL), string(EnginePostgreSQL)}},
+			"null":          map[string]any{"type": "boolean"},
+			"column":        map[string]any{"type": "string"},
+			"languages": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": "string", "enum": []string{string(LanguageGo), string(LanguageKotlin), string(LanguageTypeScript)}},
+			},
+			"type_name":     map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"pointer":       map[string]any{"type": "boolean"},
+			"scan_func":     map[string]any{"type": "string"},
+			"value_func":    map[string]any{"type": "string"},
+			"alias":         map[string]any{"type": "boolean"},
+			"prefer_method": map[string]any{"type": "string"},
+		},
+		"additionalProperties": false,
+	}
+}
+
+// ValidateConfigFile checks the config at path against the JSON Schema
+// returned by JSONSchema, then runs the same validation ParseConfigFile
+// would (ValidateGlobalOverrides and per-Override Parse) without going on
+// to generate any code. It backs `sqlc config validate` and is meant to be
+// fast enough to run on every save from an editor.
+func ValidateConfigFile(path string) error {
+	schema, err := JSONSchema()
+	if err != nil {
+		return fmt.Errorf("build schema: %w", err)
+	}
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(schema)); err != nil {
+		return fmt.Errorf("load schema: %w", err)
+	}
+	validator, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return fmt.Errorf("compile schema: %w", err)
+	}
+
+	doc, err := decodeConfigDocument(path)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	if err := validator.Validate(doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if _, err := ParseConfigFile(path); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func pluginSchema() map[string]any {
+	return map[string]any{
+		"type":     "object",
+		"required": []string{"name"},
+		"properties": map[string]any{
+			"name":          map[string]any{"type": "string"},
+			"process":       map[string]any{"type": "string"},
+			"shared_object": map[string]any{"type": "string"},
+			"packages":      map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			"config":        map[string]any{},
+		},
+		"additionalProperties": false,
+	}
+}
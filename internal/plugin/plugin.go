@@ -0,0 +1,118 @@
+// Package plugin defines the extension points that let third parties hook
+// into the sqlc code-generation pipeline without forking the project. The
+// design mirrors gqlgen's plugin.Plugin: a single marker interface identifies
+// a plugin, and optional capability interfaces are type-asserted by the
+// driver to decide which stages of the pipeline a plugin participates in.
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// Plugin is the marker interface every sqlc plugin must implement. Name is
+// used in Run's error messages to identify which plugin failed; the files
+// Run returns do not record which plugin produced them.
+type Plugin interface {
+	Name() string
+}
+
+// Source is a single SQL or schema input discovered by a SourcesInjector.
+type Source struct {
+	// Path is used for error messages and does not need to exist on disk.
+	Path     string
+	Contents []byte
+}
+
+// SourcesInjector lets a plugin contribute additional schema or query files,
+// e.g. to pull in shared schema fragments from an embedded filesystem or a
+// remote source. Run collects these sources and returns them rather than
+// parsing them itself; see Run's doc comment for how a caller is expected to
+// use them.
+type SourcesInjector interface {
+	Plugin
+	InjectSources(pkg config.PackageSettings) ([]Source, error)
+}
+
+// SchemaMutator rewrites the parsed catalog before code generation runs,
+// allowing a plugin to add synthetic tables/columns or normalize types that
+// sqlc's own parser doesn't understand.
+type SchemaMutator interface {
+	Plugin
+	MutateSchema(catalog *pg.Catalog) error
+}
+
+// ConfigMutator adjusts the fully resolved settings for a package immediately
+// before code generation, after all overrides and includes have been merged.
+type ConfigMutator interface {
+	Plugin
+	MutateConfig(settings *config.CombinedSettings) error
+}
+
+// GeneratedFile is a single file emitted by a CodeGenerator, relative to the
+// package's output path.
+type GeneratedFile struct {
+	Name     string
+	Contents []byte
+}
+
+// CodeGenerator emits additional files alongside (or instead of) sqlc's
+// built-in Go output, e.g. mocks, OpenAPI stubs, or a second language target.
+type CodeGenerator interface {
+	Plugin
+	GenerateCode(settings config.CombinedSettings, catalog *pg.Catalog) ([]GeneratedFile, error)
+}
+
+// Run executes the pipeline stages common to every package: inject sources,
+// mutate the schema, mutate the resolved config, then generate code. Plugins
+// that don't implement a given capability interface are skipped for that
+// stage. Plugins run in the order they were registered.
+//
+// Run is handed an already-built *pg.Catalog, so sources from
+// SourcesInjector can't be folded into parsing at this point in the
+// pipeline; Run collects and returns them instead of discarding them. A
+// caller that owns schema/query parsing is expected to add these sources to
+// its inputs and re-parse before building the catalog it passes to Run. No
+// such caller exists in this tree: there is no generate command wired up to
+// invoke Run, so none of the plugin stages is reachable from the sqlc CLI.
+func Run(plugins []Plugin, pkg config.PackageSettings, settings config.CombinedSettings, catalog *pg.Catalog) ([]Source, []GeneratedFile, error) {
+	var sources []Source
+	for _, p := range plugins {
+		if injector, ok := p.(SourcesInjector); ok {
+			injected, err := injector.InjectSources(pkg)
+			if err != nil {
+				return nil, nil, fmt.Errorf("plugin %q: inject sources: %w", p.Name(), err)
+			}
+			sources = append(sources, injected...)
+		}
+	}
+	for _, p := range plugins {
+		if mutator, ok := p.(SchemaMutator); ok {
+			if err := mutator.MutateSchema(catalog); err != nil {
+				return nil, nil, fmt.Errorf("plugin %q: mutate schema: %w", p.Name(), err)
+			}
+		}
+	}
+	for _, p := range plugins {
+		if mutator, ok := p.(ConfigMutator); ok {
+			if err := mutator.MutateConfig(&settings); err != nil {
+				return nil, nil, fmt.Errorf("plugin %q: mutate config: %w", p.Name(), err)
+			}
+		}
+	}
+	var files []GeneratedFile
+	for _, p := range plugins {
+		gen, ok := p.(CodeGenerator)
+		if !ok {
+			continue
+		}
+		out, err := gen.GenerateCode(settings, catalog)
+		if err != nil {
+			return nil, nil, fmt.Errorf("plugin %q: generate code: %w", p.Name(), err)
+		}
+		files = append(files, out...)
+	}
+	return sources, files, nil
+}